@@ -0,0 +1,221 @@
+package plog
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sync"
+	"sync/atomic"
+)
+
+//// ========================================================
+//// ========================================================
+//! Async logger: non-blocking logging over a bounded buffer
+
+// OverflowPolicy decides what AsyncLogger does when its buffer is full and a new record arrives.
+type OverflowPolicy int
+
+const (
+	// Drop the oldest queued record to make room for the new one
+	DropOldest OverflowPolicy = iota
+
+	// Drop the new record and keep the queue as-is
+	DropNewest
+
+	// Block the caller until the worker frees up space in the queue
+	Block
+)
+
+// asyncItem is either a rendered record to write, or a flush marker to close once the worker reaches it.
+type asyncItem struct {
+	data   []byte
+	level  int
+	msg    string
+	fields map[string]any
+	marker chan struct{}
+}
+
+/*
+AsyncLogger wraps a Logger and moves the actual write off the caller's goroutine.
+
+Records are formatted on the caller's goroutine (cheap) and queued on a bounded channel; a single background worker drains the channel and performs the write, so concurrent callers no longer contend on the writer's lock. Ordering of records is preserved.
+*/
+type AsyncLogger struct {
+	*Logger
+
+	queue   chan asyncItem
+	policy  OverflowPolicy
+	dropped atomic.Int64
+
+	// Guards queue sends against a concurrent Close: held for reading while enqueueing/flushing,
+	// for writing only while closing the queue, so a send can never race a close.
+	closeMu   sync.RWMutex
+	closed    bool
+	closeOnce sync.Once
+	wg        sync.WaitGroup
+}
+
+/*
+Return a new AsyncLogger wrapping the given Logger.
+
+BufferSize is the capacity of the internal queue. Policy controls what happens when the queue is full (see OverflowPolicy). The background worker is started immediately.
+*/
+func NewAsyncLogger(logger *Logger, bufferSize int, policy OverflowPolicy) *AsyncLogger {
+	a := &AsyncLogger{
+		Logger: logger,
+		queue:  make(chan asyncItem, bufferSize),
+		policy: policy,
+	}
+	a.wg.Add(1)
+	go a.run()
+	return a
+}
+
+// Background worker: drains the queue in order, writing records, firing hooks and unblocking flush markers as it reaches them.
+func (a *AsyncLogger) run() {
+	defer a.wg.Done()
+	for item := range a.queue {
+		if item.marker != nil {
+			close(item.marker)
+			continue
+		}
+		if err := a.Logger.writeOut(item.data); err != nil {
+			continue
+		}
+		a.Logger.fireHooks(item.level, item.msg, item.fields)
+	}
+}
+
+// Enqueue an already-rendered record, applying the configured overflow policy when the queue is full.
+// Returns an error instead of sending if the logger has already been Closed.
+func (a *AsyncLogger) enqueue(item asyncItem) error {
+	a.closeMu.RLock()
+	defer a.closeMu.RUnlock()
+	if a.closed {
+		return fmt.Errorf("async logger is closed")
+	}
+
+	select {
+	case a.queue <- item:
+		return nil
+	default:
+	}
+
+	switch a.policy {
+	case Block:
+		a.queue <- item
+	case DropNewest:
+		a.dropped.Add(1)
+	case DropOldest:
+		select {
+		case old := <-a.queue:
+			if old.marker != nil {
+				// A pending Flush waits on its marker, so the marker goes back in the queue instead of
+				// being dropped, and the new record is dropped if there is still no room for it.
+				a.queue <- old
+			} else {
+				a.dropped.Add(1)
+			}
+		default:
+		}
+		select {
+		case a.queue <- item:
+		default:
+			a.dropped.Add(1)
+		}
+	}
+	return nil
+}
+
+// Return how many records have been dropped because of the overflow policy.
+func (a *AsyncLogger) DroppedCount() int64 {
+	return a.dropped.Load()
+}
+
+func (a *AsyncLogger) print(level int, fields map[string]any, format string, attrs ...any) error {
+	msg := fmt.Sprintf(format, attrs...)
+	data, err := a.Logger.render(level, fields, 4, msg)
+	if err != nil {
+		return err
+	}
+	return a.enqueue(asyncItem{data: data, level: level, msg: msg, fields: fields})
+}
+
+/*
+Queue a log line at any selected level.
+
+Additional info depended on require value of a logger: current time, level, caller function, caller file and given message.
+*/
+func (a *AsyncLogger) Log(level int, format string, attrs ...any) error {
+	return a.print(level, nil, format, attrs...)
+}
+
+// Queue a log line with Debug level.
+func (a *AsyncLogger) Debug(format string, attrs ...any) error {
+	return a.print(LevelDebug, nil, format, attrs...)
+}
+
+// Queue a log line with Info level.
+func (a *AsyncLogger) Info(format string, attrs ...any) error {
+	return a.print(LevelInfo, nil, format, attrs...)
+}
+
+// Queue a log line with Warn level.
+func (a *AsyncLogger) Warn(format string, attrs ...any) error {
+	return a.print(LevelWarn, nil, format, attrs...)
+}
+
+// Queue a log line with Error level.
+func (a *AsyncLogger) Error(format string, attrs ...any) error {
+	return a.print(LevelError, nil, format, attrs...)
+}
+
+// Queue a log line with Fatal level, flush it through, then stop the program.
+func (a *AsyncLogger) Fatal(format string, attrs ...any) {
+	a.print(LevelFatal, nil, format, attrs...)
+	a.Flush(context.Background())
+	os.Exit(0)
+}
+
+/*
+Block until every record queued before the call has been written, or ctx is done first.
+
+Implemented by queuing a marker behind all pending records and waiting for the worker to reach it, so ordering with concurrently queued records is preserved. Returns an error without blocking if the logger has already been Closed.
+*/
+func (a *AsyncLogger) Flush(ctx context.Context) error {
+	a.closeMu.RLock()
+	if a.closed {
+		a.closeMu.RUnlock()
+		return fmt.Errorf("async logger is closed")
+	}
+	marker := make(chan struct{})
+	select {
+	case a.queue <- asyncItem{marker: marker}:
+		a.closeMu.RUnlock()
+	case <-ctx.Done():
+		a.closeMu.RUnlock()
+		return ctx.Err()
+	}
+	select {
+	case <-marker:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+/*
+Stop the background worker after draining whatever is currently queued, and release its resources.
+
+Close is safe to call more than once; subsequent calls are no-ops. Any Debug/Info/.../Flush call concurrent with or after a Close returns an error instead of sending on the closed queue.
+*/
+func (a *AsyncLogger) Close() error {
+	a.closeOnce.Do(func() {
+		a.closeMu.Lock()
+		a.closed = true
+		close(a.queue)
+		a.closeMu.Unlock()
+	})
+	a.wg.Wait()
+	return nil
+}
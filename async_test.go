@@ -0,0 +1,121 @@
+package plog
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"testing"
+	"time"
+)
+
+func TestAsyncLoggerEnqueueDropNewest(t *testing.T) {
+	a := &AsyncLogger{queue: make(chan asyncItem, 2), policy: DropNewest}
+	a.enqueue(asyncItem{data: []byte("1")})
+	a.enqueue(asyncItem{data: []byte("2")})
+	a.enqueue(asyncItem{data: []byte("3")})
+
+	if got := a.DroppedCount(); got != 1 {
+		t.Fatalf("DroppedCount() = %d, want 1", got)
+	}
+	if got := len(a.queue); got != 2 {
+		t.Fatalf("queue length = %d, want 2", got)
+	}
+}
+
+func TestAsyncLoggerEnqueueDropOldest(t *testing.T) {
+	a := &AsyncLogger{queue: make(chan asyncItem, 1), policy: DropOldest}
+	a.enqueue(asyncItem{data: []byte("old")})
+	a.enqueue(asyncItem{data: []byte("new")})
+
+	if got := a.DroppedCount(); got != 1 {
+		t.Fatalf("DroppedCount() = %d, want 1 (the evicted oldest record counts as dropped)", got)
+	}
+	item := <-a.queue
+	if string(item.data) != "new" {
+		t.Fatalf("queued item = %q, want %q", item.data, "new")
+	}
+}
+
+func TestAsyncLoggerEnqueueBlock(t *testing.T) {
+	a := &AsyncLogger{queue: make(chan asyncItem, 1), policy: Block}
+	a.enqueue(asyncItem{data: []byte("1")})
+
+	done := make(chan struct{})
+	go func() {
+		a.enqueue(asyncItem{data: []byte("2")})
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		t.Fatal("enqueue under Block returned before the queue had room")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	<-a.queue
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("enqueue under Block never unblocked after the queue freed up")
+	}
+}
+
+func TestAsyncLoggerCloseRejectsSubsequentCalls(t *testing.T) {
+	logger, err := NewLogger(LevelDebug, io.Discard, RequireAll, false)
+	if err != nil {
+		t.Fatalf("NewLogger() error = %v", err)
+	}
+	a := NewAsyncLogger(logger, 4, DropNewest)
+
+	if err := a.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+	// Close must be safe to call again.
+	if err := a.Close(); err != nil {
+		t.Fatalf("second Close() error = %v", err)
+	}
+
+	if err := a.Debug("after close"); err == nil {
+		t.Fatal("Debug() after Close() returned nil error, want an error")
+	}
+	if err := a.Flush(context.Background()); err == nil {
+		t.Fatal("Flush() after Close() returned nil error, want an error")
+	}
+}
+
+type countingHook struct {
+	levels []int
+	fired  chan struct{}
+}
+
+func (h *countingHook) Levels() []int { return h.levels }
+
+func (h *countingHook) Fire(level int, msg string, fields map[string]any) error {
+	h.fired <- struct{}{}
+	return nil
+}
+
+func TestAsyncLoggerFiresHooks(t *testing.T) {
+	logger, err := NewLogger(LevelDebug, &bytes.Buffer{}, RequireAll, false)
+	if err != nil {
+		t.Fatalf("NewLogger() error = %v", err)
+	}
+	hook := &countingHook{levels: []int{LevelInfo}, fired: make(chan struct{}, 1)}
+	logger.AddHook(hook)
+
+	a := NewAsyncLogger(logger, 4, DropNewest)
+	defer a.Close()
+
+	if err := a.Info("hello"); err != nil {
+		t.Fatalf("Info() error = %v", err)
+	}
+	if err := a.Flush(context.Background()); err != nil {
+		t.Fatalf("Flush() error = %v", err)
+	}
+
+	select {
+	case <-hook.fired:
+	default:
+		t.Fatal("hook never fired for a record logged through AsyncLogger")
+	}
+}
@@ -0,0 +1,19 @@
+//go:build !windows
+
+package plog
+
+import "os"
+
+// enableVirtualTerminal is a no-op on non-Windows platforms; their terminals already support ANSI natively.
+func enableVirtualTerminal(f *os.File) bool {
+	return true
+}
+
+// isTerminal reports whether f is attached to a terminal.
+func isTerminal(f *os.File) bool {
+	info, err := f.Stat()
+	if err != nil {
+		return false
+	}
+	return info.Mode()&os.ModeCharDevice != 0
+}
@@ -0,0 +1,41 @@
+//go:build windows
+
+package plog
+
+import (
+	"os"
+	"syscall"
+	"unsafe"
+)
+
+var (
+	kernel32           = syscall.NewLazyDLL("kernel32.dll")
+	procGetConsoleMode = kernel32.NewProc("GetConsoleMode")
+	procSetConsoleMode = kernel32.NewProc("SetConsoleMode")
+	enableVTProcessing = uint32(0x0004) // ENABLE_VIRTUAL_TERMINAL_PROCESSING
+)
+
+/*
+enableVirtualTerminal enables ANSI escape processing for f on Windows 10+ consoles via SetConsoleMode.
+
+Returns false, leaving the console mode untouched, when f isn't a console handle or the mode can't be set
+(older Windows versions); callers should fall back to uncolored output in that case.
+*/
+func enableVirtualTerminal(f *os.File) bool {
+	var mode uint32
+	if ret, _, _ := procGetConsoleMode.Call(f.Fd(), uintptr(unsafe.Pointer(&mode))); ret == 0 {
+		return false
+	}
+	if mode&enableVTProcessing != 0 {
+		return true
+	}
+	ret, _, _ := procSetConsoleMode.Call(f.Fd(), uintptr(mode|enableVTProcessing))
+	return ret != 0
+}
+
+// isTerminal reports whether f is attached to a console.
+func isTerminal(f *os.File) bool {
+	var mode uint32
+	ret, _, _ := procGetConsoleMode.Call(f.Fd(), uintptr(unsafe.Pointer(&mode)))
+	return ret != 0
+}
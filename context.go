@@ -0,0 +1,89 @@
+package plog
+
+import (
+	"context"
+	"os"
+)
+
+//// ========================================================
+//// ========================================================
+//! Context-aware logging: carry a Logger and request-scoped fields through context.Context
+
+// ContextField is a well-known context.Context key that WithContext/*Ctx methods pull onto every log line.
+type ContextField string
+
+const (
+	TraceIDField   ContextField = "trace-id"
+	SpanIDField    ContextField = "span-id"
+	RequestIDField ContextField = "request-id"
+)
+
+// contextFields lists every ContextField pulled out of a context.Context by WithContext.
+var contextFields = []ContextField{TraceIDField, SpanIDField, RequestIDField}
+
+type loggerCtxKeyType struct{}
+
+var loggerCtxKey = loggerCtxKeyType{}
+
+// Return a copy of ctx carrying l, retrievable later with LoggerFromContext.
+func ContextWithLogger(ctx context.Context, l *Logger) context.Context {
+	return context.WithValue(ctx, loggerCtxKey, l)
+}
+
+// Return the Logger previously attached with ContextWithLogger, or nil if ctx carries none.
+func LoggerFromContext(ctx context.Context) *Logger {
+	l, _ := ctx.Value(loggerCtxKey).(*Logger)
+	return l
+}
+
+/*
+WithContext pulls well-known values (trace-id, span-id, request-id) out of ctx and attaches whichever are
+present as structured fields on an Entry, for per-request correlation in HTTP servers and the like.
+*/
+func (l *Logger) WithContext(ctx context.Context) *Entry {
+	return l.WithFields(fieldsFromContext(ctx))
+}
+
+func fieldsFromContext(ctx context.Context) map[string]any {
+	fields := make(map[string]any, len(contextFields))
+	for _, key := range contextFields {
+		if value := ctx.Value(key); value != nil {
+			fields[string(key)] = value
+		}
+	}
+	return fields
+}
+
+/*
+LogCtx prints a log line at the given level, with request-scoped fields pulled from ctx (see WithContext)
+attached alongside whatever else the format/attrs produce.
+*/
+func (l *Logger) LogCtx(ctx context.Context, level int, format string, attrs ...any) error {
+	return l.print(level, fieldsFromContext(ctx), 4, format, attrs...)
+}
+
+// DebugCtx prints a log line with Debug level, with request-scoped fields pulled from ctx.
+func (l *Logger) DebugCtx(ctx context.Context, format string, attrs ...any) error {
+	return l.print(LevelDebug, fieldsFromContext(ctx), 4, format, attrs...)
+}
+
+// InfoCtx prints a log line with Info level, with request-scoped fields pulled from ctx.
+func (l *Logger) InfoCtx(ctx context.Context, format string, attrs ...any) error {
+	return l.print(LevelInfo, fieldsFromContext(ctx), 4, format, attrs...)
+}
+
+// WarnCtx prints a log line with Warn level, with request-scoped fields pulled from ctx.
+func (l *Logger) WarnCtx(ctx context.Context, format string, attrs ...any) error {
+	return l.print(LevelWarn, fieldsFromContext(ctx), 4, format, attrs...)
+}
+
+// ErrorCtx prints a log line with Error level, with request-scoped fields pulled from ctx.
+func (l *Logger) ErrorCtx(ctx context.Context, format string, attrs ...any) error {
+	return l.print(LevelError, fieldsFromContext(ctx), 4, format, attrs...)
+}
+
+// FatalCtx prints a log line with Fatal level, with request-scoped fields pulled from ctx, then stops the program.
+func (l *Logger) FatalCtx(ctx context.Context, format string, attrs ...any) {
+	l.print(LevelFatal, fieldsFromContext(ctx), 4, format, attrs...)
+	os.Exit(0)
+}
@@ -0,0 +1,91 @@
+package plog
+
+import "os"
+
+//// ========================================================
+//// ========================================================
+//! Entry: structured logging with attached fields
+
+/*
+Entry carries an immutable set of structured key/value fields bound to a Logger.
+
+Created through Logger.WithField/WithFields, and extendable further through Entry.WithField/WithFields. Logging through an Entry never mutates the underlying Logger or any other Entry derived from it.
+*/
+type Entry struct {
+	logger *Logger
+	fields map[string]any
+}
+
+/*
+Attach a single key/value field to the logger and return an Entry for structured logging.
+
+The logger itself is left untouched; the field only applies to logs made through the returned Entry.
+*/
+func (l *Logger) WithField(key string, value any) *Entry {
+	return l.WithFields(map[string]any{key: value})
+}
+
+/*
+Attach multiple key/value fields to the logger and return an Entry for structured logging.
+
+The logger itself is left untouched; the fields only apply to logs made through the returned Entry.
+*/
+func (l *Logger) WithFields(fields map[string]any) *Entry {
+	merged := make(map[string]any, len(fields))
+	for key, value := range fields {
+		merged[key] = value
+	}
+	return &Entry{logger: l, fields: merged}
+}
+
+// Attach an additional single key/value field, returning a new Entry. The receiver is left untouched.
+func (e *Entry) WithField(key string, value any) *Entry {
+	return e.WithFields(map[string]any{key: value})
+}
+
+// Attach additional key/value fields, returning a new Entry. The receiver is left untouched.
+func (e *Entry) WithFields(fields map[string]any) *Entry {
+	merged := make(map[string]any, len(e.fields)+len(fields))
+	for key, value := range e.fields {
+		merged[key] = value
+	}
+	for key, value := range fields {
+		merged[key] = value
+	}
+	return &Entry{logger: e.logger, fields: merged}
+}
+
+/*
+Print log into the underlying logger's output writer with any selected level.
+
+Additional info depended on require value of a logger: current time, level, caller function, caller file, given message and the Entry's attached fields.
+*/
+func (e *Entry) Log(level int, format string, attrs ...any) error {
+	return e.logger.print(level, e.fields, 4, format, attrs...)
+}
+
+// Print log with Debug level using the Entry's attached fields.
+func (e *Entry) Debug(format string, attrs ...any) error {
+	return e.logger.print(LevelDebug, e.fields, 4, format, attrs...)
+}
+
+// Print log with Info level using the Entry's attached fields.
+func (e *Entry) Info(format string, attrs ...any) error {
+	return e.logger.print(LevelInfo, e.fields, 4, format, attrs...)
+}
+
+// Print log with Warn level using the Entry's attached fields.
+func (e *Entry) Warn(format string, attrs ...any) error {
+	return e.logger.print(LevelWarn, e.fields, 4, format, attrs...)
+}
+
+// Print log with Error level using the Entry's attached fields.
+func (e *Entry) Error(format string, attrs ...any) error {
+	return e.logger.print(LevelError, e.fields, 4, format, attrs...)
+}
+
+// Stops the program and before that print log with Fatal level using the Entry's attached fields.
+func (e *Entry) Fatal(format string, attrs ...any) {
+	e.logger.print(LevelFatal, e.fields, 4, format, attrs...)
+	os.Exit(0)
+}
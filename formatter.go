@@ -0,0 +1,81 @@
+package plog
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+)
+
+//// ========================================================
+//// ========================================================
+//! Formatter interface and implementations
+
+// Formatter renders a single log record into bytes ready to be written to a logger's output.
+type Formatter interface {
+	Format(level int, msg string, fields map[string]any, caller, ts string) ([]byte, error)
+}
+
+// TextFormatter renders log records in the human-readable style used by the logger's default output.
+type TextFormatter struct {
+	// If true, level name is wrapped with ANSI color codes
+	Colored bool
+
+	// Bitmask of Require constants controlling which parts are included
+	Require int
+}
+
+// Format implements Formatter, reproducing the logger's original plain-text layout plus trailing "key=value" fields.
+func (f *TextFormatter) Format(level int, msg string, fields map[string]any, caller, ts string) ([]byte, error) {
+	var stats string
+	if f.Require&RequireAll != 0 {
+		stats = "-"
+		if f.Require&RequireCaller != 0 {
+			stats = fmt.Sprintf("%s %s", caller, stats)
+		}
+		if f.Require&RequireTimestamp != 0 {
+			stats = fmt.Sprintf("[%s] %s", ts, stats)
+		}
+		if f.Require&RequireLevel != 0 {
+			if f.Colored {
+				stats = fmt.Sprintf("[%s] %s", getColoredLevel(level), stats)
+			} else {
+				stats = fmt.Sprintf("[%s] %s", getRegularLevel(level), stats)
+			}
+		}
+	}
+	for _, key := range sortedFieldKeys(fields) {
+		msg = fmt.Sprintf("%s %s=%v", msg, key, fields[key])
+	}
+	return []byte(fmt.Sprintf("%s %s\n", stats, msg)), nil
+}
+
+// JSONFormatter renders log records as a single JSON object per line, with fields flattened alongside the standard keys.
+type JSONFormatter struct{}
+
+// Format implements Formatter.
+func (f *JSONFormatter) Format(level int, msg string, fields map[string]any, caller, ts string) ([]byte, error) {
+	record := make(map[string]any, len(fields)+4)
+	for key, value := range fields {
+		record[key] = value
+	}
+	record["time"] = ts
+	record["level"] = getRegularLevel(level)
+	record["msg"] = msg
+	record["caller"] = caller
+
+	data, err := json.Marshal(record)
+	if err != nil {
+		return nil, err
+	}
+	return append(data, '\n'), nil
+}
+
+// Return field keys in a deterministic, sorted order
+func sortedFieldKeys(fields map[string]any) []string {
+	keys := make([]string, 0, len(fields))
+	for key := range fields {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
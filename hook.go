@@ -0,0 +1,86 @@
+package plog
+
+//// ========================================================
+//// ========================================================
+//! Hook subsystem: fan-out to external sinks
+
+/*
+Hook lets a Logger fan a log record out to an external sink (file, syslog, HTTP endpoint, Sentry, ...) without replacing the logger's own output writer.
+
+Levels returns the set of levels a hook wants to receive; Fire is called once per matching record, after the logger has written to its primary output.
+*/
+type Hook interface {
+	// Levels returns the set of levels this hook wants to receive
+	Levels() []int
+
+	// Fire is called with a matching record's level, rendered message and structured fields
+	Fire(level int, msg string, fields map[string]any) error
+}
+
+// Register a hook so it receives every future record at a level it declares interest in.
+func (l *Logger) AddHook(hook Hook) {
+	l.mu.Lock()
+	l.hooks = append(l.hooks, hook)
+	l.mu.Unlock()
+}
+
+// Unregister a previously added hook. Does nothing if the hook is not currently registered.
+func (l *Logger) RemoveHook(hook Hook) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	for i, h := range l.hooks {
+		if h == hook {
+			l.hooks = append(l.hooks[:i], l.hooks[i+1:]...)
+			return
+		}
+	}
+}
+
+/*
+Return and clear the errors returned by hooks since the last call.
+
+Hook errors never abort a log call; they accumulate here instead so callers can inspect them on their own schedule.
+*/
+func (l *Logger) HookErrors() []error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	errs := l.hookErrs
+	l.hookErrs = nil
+	return errs
+}
+
+// Fire every registered hook whose Levels() includes level, collecting any returned errors.
+func (l *Logger) fireHooks(level int, msg string, fields map[string]any) {
+	l.mu.Lock()
+	hooks := append([]Hook(nil), l.hooks...)
+	l.mu.Unlock()
+	if len(hooks) == 0 {
+		return
+	}
+
+	var errs []error
+	for _, hook := range hooks {
+		if !hookWantsLevel(hook, level) {
+			continue
+		}
+		if err := hook.Fire(level, msg, fields); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if len(errs) == 0 {
+		return
+	}
+
+	l.mu.Lock()
+	l.hookErrs = append(l.hookErrs, errs...)
+	l.mu.Unlock()
+}
+
+func hookWantsLevel(hook Hook, level int) bool {
+	for _, lv := range hook.Levels() {
+		if lv == level {
+			return true
+		}
+	}
+	return false
+}
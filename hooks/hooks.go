@@ -0,0 +1,101 @@
+// Package hooks ships reference plog.Hook implementations for shipping logs to external sinks.
+package hooks
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/TrueHopolok/plog"
+	"github.com/TrueHopolok/plog/rotate"
+)
+
+//// ========================================================
+//// ========================================================
+//! MultiWriterHook: fan out to a fixed set of io.Writer sinks
+
+// MultiWriterHook renders every matching record with the given formatter and writes it to each of Writers.
+type MultiWriterHook struct {
+	Writers   []io.Writer
+	Formatter plog.Formatter
+	levels    []int
+}
+
+// NewMultiWriterHook returns a MultiWriterHook firing on levels and writing to writers.
+func NewMultiWriterHook(formatter plog.Formatter, levels []int, writers ...io.Writer) *MultiWriterHook {
+	return &MultiWriterHook{Writers: writers, Formatter: formatter, levels: levels}
+}
+
+// Levels implements plog.Hook.
+func (h *MultiWriterHook) Levels() []int {
+	return h.levels
+}
+
+// Fire implements plog.Hook, writing the rendered record to every configured writer.
+func (h *MultiWriterHook) Fire(level int, msg string, fields map[string]any) error {
+	data, err := h.Formatter.Format(level, msg, fields, "", time.Now().Format(time.DateTime))
+	if err != nil {
+		return err
+	}
+
+	var errs []string
+	for _, w := range h.Writers {
+		if _, err := w.Write(data); err != nil {
+			errs = append(errs, err.Error())
+		}
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("multiwriterhook: %s", strings.Join(errs, "; "))
+	}
+	return nil
+}
+
+//// ========================================================
+//// ========================================================
+//! FileRotationHook: append to a file, rotating it once it grows past a size limit
+
+/*
+FileRotationHook renders every matching record and appends it to a file, delegating the actual rotation to a
+rotate.RotatingFile once the file grows past the maxSizeBytes given to NewFileRotationHook.
+
+A maxSizeBytes <= 0 disables rotation.
+*/
+type FileRotationHook struct {
+	rf        *rotate.RotatingFile
+	formatter plog.Formatter
+	levels    []int
+}
+
+// NewFileRotationHook opens (or creates) path for appending and returns a FileRotationHook firing on levels.
+func NewFileRotationHook(path string, maxSizeBytes int64, formatter plog.Formatter, levels []int) (*FileRotationHook, error) {
+	rf, err := rotate.New(path, rotate.Options{MaxSizeBytes: maxSizeBytes})
+	if err != nil {
+		return nil, err
+	}
+	return &FileRotationHook{
+		rf:        rf,
+		formatter: formatter,
+		levels:    levels,
+	}, nil
+}
+
+// Levels implements plog.Hook.
+func (h *FileRotationHook) Levels() []int {
+	return h.levels
+}
+
+// Fire implements plog.Hook, writing the rendered record to the backing RotatingFile, which rotates itself first if needed.
+func (h *FileRotationHook) Fire(level int, msg string, fields map[string]any) error {
+	data, err := h.formatter.Format(level, msg, fields, "", time.Now().Format(time.DateTime))
+	if err != nil {
+		return err
+	}
+	_, err = h.rf.Write(data)
+	return err
+}
+
+// Close closes the backing file. The hook must not be fired again afterwards.
+func (h *FileRotationHook) Close() error {
+	return h.rf.Close()
+}
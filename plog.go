@@ -6,6 +6,7 @@ import (
 	"os"
 	"runtime"
 	"sync"
+	"sync/atomic"
 	"time"
 )
 
@@ -61,6 +62,20 @@ const (
 	colorBoldRed = "\033[1;31m"
 )
 
+/*
+AutoColor reports whether output is a terminal, for use as NewLogger's containAnsi argument.
+
+Pass plog.AutoColor(output) instead of a hardcoded true so coloring turns itself off automatically when
+output is redirected to a file or pipe.
+*/
+func AutoColor(output io.Writer) bool {
+	f, ok := output.(*os.File)
+	if !ok {
+		return false
+	}
+	return isTerminal(f)
+}
+
 //// ========================================================
 //// ========================================================
 //! Logger basic implementation
@@ -70,8 +85,9 @@ type Logger struct {
 	// Used to block any modifications with same logger
 	mu sync.Mutex
 
-	// Depending on the level, certain log functions will do nothing
-	level int
+	// Depending on the level, certain log functions will do nothing. Atomic so print's
+	// early-out check costs no mutex acquisition.
+	level atomic.Int32
 
 	// Logger will use fmt.Fprint() using an this output attribute
 	output io.Writer
@@ -79,11 +95,21 @@ type Logger struct {
 	// What logger will output additionally to message
 	require int
 
-	// If set to false, logger won't work
-	enabled bool
+	// If set to false, logger won't work. Atomic so IsLevelEnabled/V can be called from the hot
+	// path without racing SetActivity.
+	enabled atomic.Bool
 
 	// If set to true, output will contain ansi symbols
 	colored bool
+
+	// Renders a log record into bytes before it is written to output
+	formatter Formatter
+
+	// Sinks fired with every record that reaches the matching level, after the primary write
+	hooks []Hook
+
+	// Errors returned by hooks, collected instead of aborting the log call
+	hookErrs []error
 }
 
 /*
@@ -94,12 +120,30 @@ Require a valid output level and output writer.
 Requirements - additional info that will be outputed with each log (see Require constants).
 
 ContainAnsi - if true log will use ansi symbols for coloring the output (might be incompatable with windows cmd or others consoles/files).
+If output is an *os.File pointing at a Windows console, NewLogger attempts to enable ANSI processing for it
+and silently falls back to uncolored output if that isn't possible. Use AutoColor(output) to only request
+coloring when output is actually a terminal.
 */
 func NewLogger(level int, output io.Writer, outputRequirements int, containAnsi bool) (*Logger, error) {
-	if levelRange(level) {
-		return &Logger{mu: sync.Mutex{}, level: level, output: output, require: outputRequirements, enabled: true, colored: containAnsi}, nil
+	if !levelRange(level) {
+		return nil, fmt.Errorf("level value is out of range")
 	}
-	return nil, fmt.Errorf("level value is out of range")
+	colored := containAnsi
+	if colored {
+		if f, ok := output.(*os.File); ok {
+			colored = enableVirtualTerminal(f)
+		}
+	}
+	l := &Logger{
+		mu:        sync.Mutex{},
+		output:    output,
+		require:   outputRequirements,
+		colored:   colored,
+		formatter: &TextFormatter{Colored: colored, Require: outputRequirements},
+	}
+	l.level.Store(int32(level))
+	l.enabled.Store(true)
+	return l, nil
 }
 
 /*
@@ -108,15 +152,29 @@ Change logger's level to a given value.
 In case of level being out of range, return error and do not change logger in any way.
 */
 func (l *Logger) SetLevel(level int) error {
-	if levelRange(level) {
+	if !levelRange(level) {
 		return fmt.Errorf("level value is out of range")
 	}
-	l.mu.Lock()
-	l.level = level
-	l.mu.Unlock()
+	l.level.Store(int32(level))
 	return nil
 }
 
+// Return whether a log at the given level would currently be written, without formatting anything.
+func (l *Logger) IsLevelEnabled(level int) bool {
+	return l.enabled.Load() && int(l.level.Load()) <= level
+}
+
+/*
+V reports whether level is enabled, so callers can skip building expensive log arguments when it is not:
+
+	if l.V(plog.LevelDebug) {
+		l.Debug("state: %s", expensiveDump())
+	}
+*/
+func (l *Logger) V(level int) bool {
+	return l.IsLevelEnabled(level)
+}
+
 // Change logger's writer interface to a given one
 func (l *Logger) SetWriter(output io.Writer) {
 	l.mu.Lock()
@@ -132,14 +190,15 @@ Expects usage of Require bitmask constants.
 func (l *Logger) SetRequirements(outputRequirements int) {
 	l.mu.Lock()
 	l.require = outputRequirements
+	if tf, ok := l.formatter.(*TextFormatter); ok {
+		tf.Require = outputRequirements
+	}
 	l.mu.Unlock()
 }
 
 // Change logger's enabled field to a given value
 func (l *Logger) SetActivity(enabled bool) {
-	l.mu.Lock()
-	l.enabled = enabled
-	l.mu.Unlock()
+	l.enabled.Store(enabled)
 }
 
 /*
@@ -150,6 +209,20 @@ If true, will use ANSI characters.
 func (l *Logger) SetColoring(containAnsi bool) {
 	l.mu.Lock()
 	l.colored = containAnsi
+	if tf, ok := l.formatter.(*TextFormatter); ok {
+		tf.Colored = containAnsi
+	}
+	l.mu.Unlock()
+}
+
+/*
+Change logger's formatter to a given one.
+
+Formatter controls how level, fields, caller and timestamp are rendered into the bytes written to output. See TextFormatter and JSONFormatter.
+*/
+func (l *Logger) SetFormatter(formatter Formatter) {
+	l.mu.Lock()
+	l.formatter = formatter
 	l.mu.Unlock()
 }
 
@@ -216,39 +289,51 @@ func getCall(skip int) string {
 //// ========================================================
 //! Logger output implementation
 
-// Using given values print them in the logger's output writer attribute
-func (l *Logger) print(level int, format string, attrs ...any) error {
-	if !l.enabled {
-		return fmt.Errorf("logger is disabled")
-	}
-	if l.level > level {
-		return fmt.Errorf("logger ingores given level logs")
+/*
+Run the level check and render a log record's message through the logger's formatter, without writing it anywhere.
+
+Fields may be nil; it carries the structured key/value context attached through WithField/WithFields/Entry. Skip is forwarded to getCall and must account for the number of plog-internal frames between here and the original caller. Split out from print so AsyncLogger can format on the caller's goroutine and defer the write to its background worker.
+*/
+func (l *Logger) render(level int, fields map[string]any, skip int, msg string) ([]byte, error) {
+	if !l.enabled.Load() {
+		return nil, fmt.Errorf("logger is disabled")
 	}
-	var stats string
-	if l.require&RequireAll != 0 {
-		stats = "-"
-		if l.require&RequireCaller != 0 {
-			stats = fmt.Sprintf("%s %s", getCall(3), stats)
-		}
-		if l.require&RequireTimestamp != 0 {
-			stats = fmt.Sprintf("[%s] %s", getTimestamp(), stats)
-		}
-		if l.require&RequireLevel != 0 {
-			if l.colored {
-				stats = fmt.Sprintf("[%s] %s", getColoredLevel(level), stats)
-			} else {
-				stats = fmt.Sprintf("[%s] %s", getRegularLevel(level), stats)
-			}
-		}
+	if int(l.level.Load()) > level {
+		return nil, fmt.Errorf("logger ingores given level logs")
 	}
+	caller := getCall(skip)
+	ts := getTimestamp()
+
+	l.mu.Lock()
+	formatter := l.formatter
+	l.mu.Unlock()
+
+	return formatter.Format(level, msg, fields, caller, ts)
+}
+
+// Write an already-rendered log record into the logger's output writer attribute.
+func (l *Logger) writeOut(data []byte) error {
 	l.mu.Lock()
 	defer l.mu.Unlock()
-	fmt.Fprintf(
-		l.output,
-		"%s %s\n",
-		stats,
-		fmt.Sprintf(format, attrs...),
-	)
+	_, err := l.output.Write(data)
+	return err
+}
+
+/*
+Using given values, render them through the logger's formatter, write the result into the logger's output writer attribute, then fire matching hooks.
+
+Fields may be nil; it carries the structured key/value context attached through WithField/WithFields/Entry/*Ctx. Skip is forwarded to render/getCall and must account for the number of plog-internal frames between here and the original caller.
+*/
+func (l *Logger) print(level int, fields map[string]any, skip int, format string, attrs ...any) error {
+	msg := fmt.Sprintf(format, attrs...)
+	data, err := l.render(level, fields, skip, msg)
+	if err != nil {
+		return err
+	}
+	if err := l.writeOut(data); err != nil {
+		return err
+	}
+	l.fireHooks(level, msg, fields)
 	return nil
 }
 
@@ -260,7 +345,7 @@ Takes formated string and output it with additional info.
 Additional info depended on require value of a logger: current time, level, caller function, caller file and given message.
 */
 func (l *Logger) Log(level int, format string, attrs ...any) error {
-	return l.print(level, format, attrs...)
+	return l.print(level, nil, 4, format, attrs...)
 }
 
 /*
@@ -271,7 +356,7 @@ Takes formated string and output it with additional info.
 Additional info depended on require value of a logger: current time, level, caller function, caller file and given message
 */
 func (l *Logger) Debug(format string, attrs ...any) error {
-	return l.print(LevelDebug, format, attrs...)
+	return l.print(LevelDebug, nil, 4, format, attrs...)
 }
 
 /*
@@ -282,7 +367,7 @@ Takes formated string and output it with additional info.
 Additional info depended on require value of a logger: current time, level, caller function, caller file and given message.
 */
 func (l *Logger) Info(format string, attrs ...any) error {
-	return l.print(LevelInfo, format, attrs...)
+	return l.print(LevelInfo, nil, 4, format, attrs...)
 }
 
 /*
@@ -293,7 +378,7 @@ Takes formated string and output it with additional info.
 Additional info depended on require value of a logger: current time, level, caller function, caller file and given message.
 */
 func (l *Logger) Warn(format string, attrs ...any) error {
-	return l.print(LevelWarn, format, attrs...)
+	return l.print(LevelWarn, nil, 4, format, attrs...)
 }
 
 /*
@@ -304,7 +389,7 @@ Takes formated string and output it with additional info.
 Additional info depended on require value of a logger: current time, level, caller function, caller file and given message.
 */
 func (l *Logger) Error(format string, attrs ...any) error {
-	return l.print(LevelError, format, attrs...)
+	return l.print(LevelError, nil, 4, format, attrs...)
 }
 
 /*
@@ -315,7 +400,7 @@ Takes formated string and output it with additional info.
 Additional info depended on require value of a logger: current time, level, caller function, caller file and given message.
 */
 func (l *Logger) Fatal(format string, attrs ...any) {
-	l.print(LevelFatal, format, attrs...)
+	l.print(LevelFatal, nil, 4, format, attrs...)
 	os.Exit(0)
 }
 
@@ -0,0 +1,222 @@
+// Package rotate provides a size- and time-based rotating file io.Writer for use with plog.NewLogger/SetWriter.
+package rotate
+
+import (
+	"compress/gzip"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Options configures a RotatingFile's rotation thresholds and backup handling.
+type Options struct {
+	// Rotate once the active file would grow past this size. <= 0 disables size-based rotation.
+	MaxSizeBytes int64
+
+	// Rotate once the active file is at least this old. Age counts from the file's modification time when it
+	// was opened, which for a freshly created file is its creation. <= 0 disables time-based rotation.
+	MaxAgeDuration time.Duration
+
+	// Keep at most this many rotated backups, deleting the oldest first. <= 0 keeps them all.
+	MaxBackups int
+
+	// Gzip rotated backups.
+	Compress bool
+
+	// Backup filename pattern; "%s" is replaced with a timestamp. Empty uses "<name>-<timestamp><ext>".
+	Pattern string
+}
+
+/*
+RotatingFile is an io.Writer that appends to path, rotating it aside once a size or age threshold configured
+through Options is exceeded. Safe for concurrent use.
+*/
+type RotatingFile struct {
+	mu        sync.Mutex
+	path      string
+	opts      Options
+	file      *os.File
+	size      int64
+	createdAt time.Time
+}
+
+// New opens (or creates) path for appending and returns a RotatingFile governed by opts.
+func New(path string, opts Options) (*RotatingFile, error) {
+	rf := &RotatingFile{path: path, opts: opts}
+	if err := rf.openCurrent(); err != nil {
+		return nil, err
+	}
+	return rf, nil
+}
+
+func (rf *RotatingFile) openCurrent() error {
+	file, err := os.OpenFile(rf.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return err
+	}
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return err
+	}
+	rf.file = file
+	rf.size = info.Size()
+	rf.createdAt = info.ModTime()
+	return nil
+}
+
+// Write implements io.Writer, rotating first if p would push the active file past a configured threshold.
+func (rf *RotatingFile) Write(p []byte) (int, error) {
+	rf.mu.Lock()
+	defer rf.mu.Unlock()
+	if rf.shouldRotateLocked(int64(len(p))) {
+		if err := rf.rotateLocked(); err != nil {
+			return 0, err
+		}
+	}
+	n, err := rf.file.Write(p)
+	rf.size += int64(n)
+	return n, err
+}
+
+func (rf *RotatingFile) shouldRotateLocked(next int64) bool {
+	if rf.opts.MaxSizeBytes > 0 && rf.size+next > rf.opts.MaxSizeBytes {
+		return true
+	}
+	if rf.opts.MaxAgeDuration > 0 && time.Since(rf.createdAt) >= rf.opts.MaxAgeDuration {
+		return true
+	}
+	return false
+}
+
+/*
+Rotate closes the active file, renames it aside (compressing it if Compress is set), prunes old backups
+past MaxBackups and opens a fresh active file. Exposed for manual/SIGHUP-driven rotation.
+*/
+func (rf *RotatingFile) Rotate() error {
+	rf.mu.Lock()
+	defer rf.mu.Unlock()
+	return rf.rotateLocked()
+}
+
+func (rf *RotatingFile) rotateLocked() error {
+	if err := rf.file.Close(); err != nil {
+		return err
+	}
+	backupPath := rf.backupName()
+	if err := os.Rename(rf.path, backupPath); err != nil {
+		return err
+	}
+	if rf.opts.Compress {
+		if err := compressFile(backupPath); err != nil {
+			return err
+		}
+	}
+	if err := rf.openCurrent(); err != nil {
+		return err
+	}
+	return rf.pruneBackupsLocked()
+}
+
+func (rf *RotatingFile) backupName() string {
+	ts := time.Now().Format("20060102T150405.000000000")
+	if rf.opts.Pattern != "" {
+		return fmt.Sprintf(rf.opts.Pattern, ts)
+	}
+	ext := filepath.Ext(rf.path)
+	base := strings.TrimSuffix(rf.path, ext)
+	return fmt.Sprintf("%s-%s%s", base, ts, ext)
+}
+
+// backupGlob returns the glob pattern matching every backup backupName can produce, so pruneBackupsLocked
+// enforces MaxBackups against a custom Pattern the same way it does against the default naming scheme.
+func (rf *RotatingFile) backupGlob() string {
+	if rf.opts.Pattern != "" {
+		return strings.ReplaceAll(rf.opts.Pattern, "%s", "*") + "*"
+	}
+	ext := filepath.Ext(rf.path)
+	base := strings.TrimSuffix(rf.path, ext)
+	return base + "-*" + ext + "*"
+}
+
+func (rf *RotatingFile) pruneBackupsLocked() error {
+	if rf.opts.MaxBackups <= 0 {
+		return nil
+	}
+	matches, err := filepath.Glob(rf.backupGlob())
+	if err != nil {
+		return err
+	}
+	if len(matches) <= rf.opts.MaxBackups {
+		return nil
+	}
+
+	sort.Slice(matches, func(i, j int) bool {
+		fi, errI := os.Stat(matches[i])
+		fj, errJ := os.Stat(matches[j])
+		if errI != nil || errJ != nil {
+			return false
+		}
+		return fi.ModTime().Before(fj.ModTime())
+	})
+
+	for _, stale := range matches[:len(matches)-rf.opts.MaxBackups] {
+		os.Remove(stale)
+	}
+	return nil
+}
+
+/*
+Reopen closes and reopens the file at path, picking up a fresh inode.
+
+Intended for external logrotate-style integrations: after logrotate renames the file out from under a running
+process, a SIGHUP handler calling Reopen lets writes resume against a freshly created file at the same path.
+*/
+func (rf *RotatingFile) Reopen() error {
+	rf.mu.Lock()
+	defer rf.mu.Unlock()
+	if rf.file != nil {
+		rf.file.Close()
+	}
+	return rf.openCurrent()
+}
+
+// Close closes the active file. The RotatingFile must not be written to afterwards.
+func (rf *RotatingFile) Close() error {
+	rf.mu.Lock()
+	defer rf.mu.Unlock()
+	return rf.file.Close()
+}
+
+func compressFile(path string) error {
+	src, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer src.Close()
+
+	dst, err := os.Create(path + ".gz")
+	if err != nil {
+		return err
+	}
+
+	gz := gzip.NewWriter(dst)
+	if _, err := io.Copy(gz, src); err != nil {
+		gz.Close()
+		dst.Close()
+		return err
+	}
+	if err := gz.Close(); err != nil {
+		dst.Close()
+		return err
+	}
+	if err := dst.Close(); err != nil {
+		return err
+	}
+	return os.Remove(path)
+}
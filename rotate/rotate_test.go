@@ -0,0 +1,115 @@
+package rotate
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func globCount(t *testing.T, pattern string) int {
+	t.Helper()
+	matches, err := filepath.Glob(pattern)
+	if err != nil {
+		t.Fatalf("Glob(%q) error = %v", pattern, err)
+	}
+	return len(matches)
+}
+
+func TestRotatingFileRotatesPastMaxSize(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "app.log")
+
+	rf, err := New(path, Options{MaxSizeBytes: 10})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	defer rf.Close()
+
+	for i := 0; i < 5; i++ {
+		if _, err := rf.Write([]byte("0123456789")); err != nil {
+			t.Fatalf("Write() error = %v", err)
+		}
+	}
+
+	if got := globCount(t, filepath.Join(dir, "app-*.log*")); got == 0 {
+		t.Fatal("expected at least one rotated backup, found none")
+	}
+}
+
+func TestRotatingFileEnforcesMaxBackupsWithDefaultPattern(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "app.log")
+
+	rf, err := New(path, Options{MaxSizeBytes: 10, MaxBackups: 1})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	defer rf.Close()
+
+	for i := 0; i < 5; i++ {
+		if _, err := rf.Write([]byte("0123456789")); err != nil {
+			t.Fatalf("Write() error = %v", err)
+		}
+	}
+
+	if got := globCount(t, filepath.Join(dir, "app-*.log*")); got != 1 {
+		t.Fatalf("backup count = %d, want 1 (MaxBackups not enforced)", got)
+	}
+}
+
+func TestRotatingFileEnforcesMaxBackupsWithCustomPattern(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "app.log")
+
+	rf, err := New(path, Options{
+		MaxSizeBytes: 10,
+		MaxBackups:   1,
+		Pattern:      filepath.Join(dir, "custom-%s.log"),
+	})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	defer rf.Close()
+
+	for i := 0; i < 5; i++ {
+		if _, err := rf.Write([]byte("0123456789")); err != nil {
+			t.Fatalf("Write() error = %v", err)
+		}
+	}
+
+	if got := globCount(t, filepath.Join(dir, "custom-*.log*")); got != 1 {
+		t.Fatalf("backup count = %d, want 1 (MaxBackups not enforced for a custom Pattern)", got)
+	}
+}
+
+func TestRotatingFileReopen(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "app.log")
+
+	rf, err := New(path, Options{})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	defer rf.Close()
+
+	if _, err := rf.Write([]byte("before")); err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+	if err := os.Rename(path, path+".rotated"); err != nil {
+		t.Fatalf("Rename() error = %v", err)
+	}
+	if err := rf.Reopen(); err != nil {
+		t.Fatalf("Reopen() error = %v", err)
+	}
+	if _, err := rf.Write([]byte("after")); err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("ReadFile() error = %v", err)
+	}
+	if string(data) != "after" {
+		t.Fatalf("file contents = %q, want %q", data, "after")
+	}
+}